@@ -0,0 +1,226 @@
+package main
+
+import (
+	"errors"
+	"net/rpc"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// shard is one partition of the left x right column pair space that a
+// mapper can execute independently of the others.
+type shard struct {
+	Left, Right  []ColumnProfile
+	ExactOverlap bool
+}
+
+// mapper executes a shard's pairwise scoring, either in-process or on a
+// remote dataswipe serve peer. Mirrors the local/remote split InfluxDB's
+// ShardMapper uses for distributed query execution.
+type mapper interface {
+	mapShard(s shard) ([]ColumnProfilePairScores, error)
+}
+
+// shardMapper hands out a mapper per shard, round-robining shards across
+// every configured peer plus the local worker pool - local counts as one
+// more destination in the rotation, not a fallback used only when no peers
+// are configured, so --workers keeps pulling its weight once --peers is set.
+type shardMapper struct {
+	workers int
+	peers   []string
+
+	mu   sync.Mutex
+	next int
+}
+
+func newShardMapper(workers int, peers []string) *shardMapper {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return &shardMapper{workers: workers, peers: peers}
+}
+
+// createMapper returns the mapper for the next shard, round-robining across
+// sm.peers with the local worker pool as one additional destination (the
+// last slot in the rotation).
+func (sm *shardMapper) createMapper() mapper {
+	sm.mu.Lock()
+	dest := sm.next % (len(sm.peers) + 1)
+	sm.next++
+	sm.mu.Unlock()
+
+	if dest == len(sm.peers) {
+		return &localMapper{workers: sm.workers}
+	}
+	return &remoteMapper{addr: sm.peers[dest]}
+}
+
+// localMapper runs a shard's left x right column pairs through match() on
+// a fixed pool of worker goroutines, gating the overlap term by the same
+// LSH candidate check matchProfile uses so a sharded --workers/--peers run
+// scores identically to a single-process one.
+type localMapper struct {
+	workers int
+}
+
+func (m *localMapper) mapShard(s shard) ([]ColumnProfilePairScores, error) {
+	type job struct {
+		left, right      ColumnProfile
+		overlapCandidate bool
+	}
+
+	workers := m.workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	jobs := make(chan job)
+	results := make(chan ColumnProfilePairScores)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results <- match(j.left, j.right, s.ExactOverlap, j.overlapCandidate)
+			}
+		}()
+	}
+
+	go func() {
+		idx := buildOverlapIndex(s.Right, s.ExactOverlap)
+		for _, left := range s.Left {
+			candidates := overlapCandidates(idx, left)
+			for j, right := range s.Right {
+				_, isCandidate := candidates[j]
+				jobs <- job{left, right, idx == nil || isCandidate}
+			}
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var scores []ColumnProfilePairScores
+	for r := range results {
+		scores = append(scores, r)
+	}
+	return scores, nil
+}
+
+// remoteMapper ships a shard to a peer dataswipe serve node's MapperService
+// and returns the scores it computes. Only the profiles in the shard (not
+// raw source files) cross the wire, gob-encoded by net/rpc rather than
+// gRPC: shard/ColumnProfilePairScores are plain Go structs already shared
+// in-process, RPC traffic stays internal to a --peers cluster we control on
+// both ends, and stdlib net/rpc gives us that with no protoc/codegen step
+// or extra dependency.
+type remoteMapper struct {
+	addr string
+}
+
+func (m *remoteMapper) mapShard(s shard) ([]ColumnProfilePairScores, error) {
+	client, err := rpc.Dial("tcp", m.addr)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	var scores []ColumnProfilePairScores
+	if err := client.Call("MapperService.MapShard", s, &scores); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// partitionShards splits leftCps x rightCps into up to n shards by chunking
+// the left-hand columns, pairing each chunk with the full right-hand side.
+// Each shard still covers the full left-chunk x rightCps space - the same
+// LSH-gated overlap narrowing matchProfile does happens inside mapShard, not
+// here, so a sharded run scores the same pairs a single-process run would.
+func partitionShards(leftCps, rightCps []ColumnProfile, n int, exactOverlap bool) []shard {
+	if n <= 0 {
+		n = 1
+	}
+	if len(leftCps) == 0 {
+		return nil
+	}
+
+	chunk := (len(leftCps) + n - 1) / n
+	if chunk == 0 {
+		chunk = len(leftCps)
+	}
+
+	var shards []shard
+	for i := 0; i < len(leftCps); i += chunk {
+		end := i + chunk
+		if end > len(leftCps) {
+			end = len(leftCps)
+		}
+		shards = append(shards, shard{Left: leftCps[i:end], Right: rightCps, ExactOverlap: exactOverlap})
+	}
+	return shards
+}
+
+// distributedMatchProfile is matchProfile split across shards, each run
+// either locally or on a --peers node, then merged back into one
+// deduplicated, descending-score result set. A shard failure (e.g. an
+// unreachable peer) does not abort the others, but is aggregated into the
+// returned error rather than silently dropped, so a caller can tell the
+// result set is incomplete.
+func distributedMatchProfile(leftCps, rightCps []ColumnProfile, workers int, peers []string, exactOverlap bool) ([]ColumnProfilePairScores, error) {
+	sm := newShardMapper(workers, peers)
+	shards := partitionShards(leftCps, rightCps, workers+len(peers), exactOverlap)
+
+	type shardResult struct {
+		scores []ColumnProfilePairScores
+		err    error
+	}
+
+	resultsCh := make(chan shardResult, len(shards))
+	var wg sync.WaitGroup
+	for _, sh := range shards {
+		wg.Add(1)
+		go func(sh shard) {
+			defer wg.Done()
+			scores, err := sm.createMapper().mapShard(sh)
+			resultsCh <- shardResult{scores, err}
+		}(sh)
+	}
+	wg.Wait()
+	close(resultsCh)
+
+	seen := make(map[ColumnProfilePair]ColumnProfilePairScores)
+	var errs []error
+	for r := range resultsCh {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		for _, v := range r.scores {
+			cpp := NewColumnProfilePair(v.Left, v.Right)
+			if _, exists := seen[cpp]; exists {
+				continue
+			}
+			cppInversed := NewColumnProfilePair(v.Right, v.Left)
+			if _, exists := seen[cppInversed]; exists {
+				continue
+			}
+			seen[cpp] = v
+		}
+	}
+
+	var results []ColumnProfilePairScores
+	for _, v := range seen {
+		results = append(results, v)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score // descending
+	})
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,65 @@
+package main
+
+import "testing"
+
+func TestShardMapperCreateMapperRotatesLocalWithPeers(t *testing.T) {
+	sm := newShardMapper(4, []string{"peer-a:8081", "peer-b:8081"})
+
+	var got []string
+	for i := 0; i < 6; i++ {
+		switch m := sm.createMapper().(type) {
+		case *localMapper:
+			got = append(got, "local")
+		case *remoteMapper:
+			got = append(got, m.addr)
+		default:
+			t.Fatalf("createMapper returned unexpected type %T", m)
+		}
+	}
+
+	want := []string{
+		"peer-a:8081", "peer-b:8081", "local",
+		"peer-a:8081", "peer-b:8081", "local",
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("createMapper() sequence = %v, want %v", got, want)
+		}
+	}
+}
+
+// TestLocalMapperMapShardMatchesMatchProfile checks that the distributed
+// (--workers/--peers) path scores a pair whose samples share no LSH band the
+// same way the single-process matchProfile does: one pair, same score.
+func TestLocalMapperMapShardMatchesMatchProfile(t *testing.T) {
+	left := ColumnProfile{Name: "email", DType: VarChar, UniquePct: 100, Samples: []string{"alpha", "bravo", "charlie", "delta", "echo"}}
+	left.Signature = minHashSignature(left.Samples)
+	right := ColumnProfile{Name: "email", DType: VarChar, UniquePct: 100, Samples: []string{"111111", "222222", "333333", "444444", "555555"}}
+	right.Signature = minHashSignature(right.Samples)
+
+	want := matchProfile([]ColumnProfile{left}, []ColumnProfile{right}, false)
+
+	lm := &localMapper{workers: 2}
+	got, err := lm.mapShard(shard{Left: []ColumnProfile{left}, Right: []ColumnProfile{right}, ExactOverlap: false})
+	if err != nil {
+		t.Fatalf("mapShard returned error: %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("mapShard returned %d pairs, want %d", len(got), len(want))
+	}
+	if got[0].Score != want[0].Score {
+		t.Fatalf("mapShard score = %v, want %v", got[0].Score, want[0].Score)
+	}
+}
+
+func TestShardMapperCreateMapperLocalOnlyWithNoPeers(t *testing.T) {
+	sm := newShardMapper(2, nil)
+
+	for i := 0; i < 3; i++ {
+		m := sm.createMapper()
+		if _, ok := m.(*localMapper); !ok {
+			t.Fatalf("createMapper() with no peers returned %T, want *localMapper", m)
+		}
+	}
+}
@@ -38,23 +38,37 @@ type ColumnProfilePairScores struct {
 }
 
 func runMatch(m MatchCmd) ([]ColumnProfilePairScores, error) {
-	leftCps, err := profilePath(m.LeftPath, m.SampleSize)
+	leftCps, err := profileSide(m.LeftPath, m.LeftSource, m.SampleSize)
 	if err != nil {
 		return []ColumnProfilePairScores{}, err
 	}
-	rightCps, err := profilePath(m.RightPath, m.SampleSize)
+	rightCps, err := profileSide(m.RightPath, m.RightSource, m.SampleSize)
 	if err != nil {
 		return []ColumnProfilePairScores{}, err
 	}
 
-	scores := matchProfile(leftCps, rightCps)
-	return scores, nil
+	exactOverlap := resolveExactOverlap(m.ExactOverlap, leftCps, rightCps)
+	if m.Workers > 0 || len(m.Peers) > 0 {
+		return distributedMatchProfile(leftCps, rightCps, m.Workers, m.Peers, exactOverlap)
+	}
+	return matchProfile(leftCps, rightCps, exactOverlap), nil
 }
 
-func matchProfile(leftCps, rightCps []ColumnProfile) []ColumnProfilePairScores {
+// matchProfile scores every left x right column pair - name, type, null-pct,
+// and unique-pct always weigh in, so a renamed-but-equivalent column still
+// surfaces even when its sample values don't overlap. By default, only the
+// overlap term is narrowed by an LSH index over each column's MinHash
+// signature: pairs sharing no band are cheaper to score (overlap counts as
+// 0 rather than running estimateJaccard) rather than dropped outright. Pass
+// exactOverlap to fall back to the original Cartesian Levenshtein overlap
+// for small inputs.
+func matchProfile(leftCps, rightCps []ColumnProfile, exactOverlap bool) []ColumnProfilePairScores {
+	idx := buildOverlapIndex(rightCps, exactOverlap)
+
 	scores := make(map[ColumnProfilePair]ColumnProfilePairScores)
 	for _, left := range leftCps {
-		for _, right := range rightCps {
+		candidates := overlapCandidates(idx, left)
+		for j, right := range rightCps {
 			cpp := NewColumnProfilePair(left, right)
 			if _, exists := scores[cpp]; exists {
 				continue
@@ -63,7 +77,8 @@ func matchProfile(leftCps, rightCps []ColumnProfile) []ColumnProfilePairScores {
 			if _, exists := scores[cppInversed]; exists {
 				continue
 			}
-			scores[cpp] = match(left, right)
+			_, isCandidate := candidates[j]
+			scores[cpp] = match(left, right, exactOverlap, idx == nil || isCandidate)
 		}
 	}
 	var results []ColumnProfilePairScores
@@ -76,13 +91,54 @@ func matchProfile(leftCps, rightCps []ColumnProfile) []ColumnProfilePairScores {
 	return results
 }
 
-func match(left, right ColumnProfile) ColumnProfilePairScores {
+// buildOverlapIndex returns an LSH index over rightCps' MinHash signatures,
+// or nil when exactOverlap means no index is needed at all.
+func buildOverlapIndex(rightCps []ColumnProfile, exactOverlap bool) *lshIndex {
+	if exactOverlap {
+		return nil
+	}
+	idx := newLSHIndex()
+	for j, right := range rightCps {
+		idx.add(j, right.Signature)
+	}
+	return idx
+}
+
+// overlapCandidates returns the rightCps indices idx considers overlap
+// candidates for left, as a set for O(1) membership checks. A nil idx
+// (exact-overlap mode) yields a nil set, which callers treat as "every
+// index is a candidate".
+func overlapCandidates(idx *lshIndex, left ColumnProfile) map[int]struct{} {
+	if idx == nil {
+		return nil
+	}
+	candidates := make(map[int]struct{})
+	for _, j := range idx.candidates(left.Signature) {
+		candidates[j] = struct{}{}
+	}
+	return candidates
+}
+
+// match scores a left/right column pair. overlapCandidate gates the overlap
+// term: when false (left and right share no LSH band), overlap counts as 0
+// instead of being estimated, but name/type/null/unique are scored
+// regardless - those terms carry 85% of the weight and don't depend on
+// sample-value overlap at all.
+func match(left, right ColumnProfile, exactOverlap, overlapCandidate bool) ColumnProfilePairScores {
 	typeScore := baseTypeScore(left.DType, right.DType)
 	nullScore := nullSimilarityScore(left.NullPct, right.NullPct)
 	uniqueScore := uniqueScore(left.UniquePct, right.UniquePct)
-	overlapScore := overlapScore(left.Samples, right.Samples, 0.8)
+
+	var overlap float64
+	switch {
+	case exactOverlap:
+		overlap = overlapScore(left.Samples, right.Samples, 0.8)
+	case overlapCandidate:
+		overlap = estimateJaccard(left.Signature, right.Signature)
+	}
+
 	columnNameScore := columnNameScore(left.Name, right.Name)
-	score := 0.3*columnNameScore + 0.25*typeScore + 0.2*uniqueScore + 0.15*overlapScore + 0.1*nullScore
+	score := 0.3*columnNameScore + 0.25*typeScore + 0.2*uniqueScore + 0.15*overlap + 0.1*nullScore
 	return ColumnProfilePairScores{score, left, right}
 }
 
@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+// TestMatchProfileScoresNonLSHCandidatePairs guards against matchProfile
+// silently dropping a pair just because its MinHash signatures share no LSH
+// band: two same-named, same-typed columns whose samples don't overlap at
+// all should still surface, on the strength of name/type/null/unique alone.
+func TestMatchProfileScoresNonLSHCandidatePairs(t *testing.T) {
+	left := ColumnProfile{
+		Name:      "email",
+		DType:     VarChar,
+		NullPct:   0,
+		UniquePct: 100,
+		Samples:   []string{"alpha", "bravo", "charlie", "delta", "echo"},
+	}
+	left.Signature = minHashSignature(left.Samples)
+
+	right := ColumnProfile{
+		Name:      "email",
+		DType:     VarChar,
+		NullPct:   0,
+		UniquePct: 100,
+		Samples:   []string{"111111", "222222", "333333", "444444", "555555"},
+	}
+	right.Signature = minHashSignature(right.Samples)
+
+	idx := newLSHIndex()
+	idx.add(0, right.Signature)
+	if candidates := idx.candidates(left.Signature); len(candidates) != 0 {
+		t.Fatalf("test fixture invalid: expected no shared LSH band, got candidates %v", candidates)
+	}
+
+	results := matchProfile([]ColumnProfile{left}, []ColumnProfile{right}, false)
+	if len(results) != 1 {
+		t.Fatalf("matchProfile returned %d pairs, want 1 (the pair should score, not be dropped)", len(results))
+	}
+	if results[0].Score <= 0 {
+		t.Fatalf("matchProfile score = %v, want > 0 from name/type/null/unique alone", results[0].Score)
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import "strings"
+
+const (
+	minHashFunctions = 128                         // K
+	lshBands         = 16                          // b
+	lshRows          = minHashFunctions / lshBands // r, K = b*r
+)
+
+// minHashSeeds are K odd multiplier constants used to build K independent
+// hash functions over the same 64-bit base hash (h_i(x) = seed_i * x, the
+// standard universal-hashing trick for cheap independent hash families).
+var minHashSeeds = generateMinHashSeeds(minHashFunctions)
+
+func generateMinHashSeeds(k int) []uint64 {
+	seeds := make([]uint64, k)
+	seed := uint64(0x9E3779B97F4A7C15) // golden ratio constant, odd
+	for i := range seeds {
+		seed = seed*6364136223846793005 + 1442695040888963407
+		if seed%2 == 0 {
+			seed++
+		}
+		seeds[i] = seed
+	}
+	return seeds
+}
+
+// canonicalize normalizes a sample value before hashing, then shingles it
+// into character 3-grams, so near-duplicates like "Jon Smith"/"Jon  Smith"
+// collide instead of missing each other entirely.
+func canonicalize(v string) []string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(v)), " ")
+	if normalized == "" {
+		return nil
+	}
+	if len(normalized) < 3 {
+		return []string{normalized}
+	}
+
+	runes := []rune(normalized)
+	shingles := make([]string, 0, len(runes)-2)
+	for i := 0; i+3 <= len(runes); i++ {
+		shingles = append(shingles, string(runes[i:i+3]))
+	}
+	return shingles
+}
+
+func fnv64(s string) uint64 {
+	const (
+		offset = 14695981039346656037
+		prime  = 1099511628211
+	)
+	h := uint64(offset)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= prime
+	}
+	return h
+}
+
+// minHashSignature computes a K-function MinHash signature over a column's
+// sample value multiset: every value's shingles are hashed K times and the
+// per-function minimum across all values is kept.
+func minHashSignature(values []string) []uint64 {
+	sig := make([]uint64, minHashFunctions)
+	for i := range sig {
+		sig[i] = ^uint64(0)
+	}
+
+	for _, v := range values {
+		for _, shingle := range canonicalize(v) {
+			base := fnv64(shingle)
+			for i, seed := range minHashSeeds {
+				if h := base * seed; h < sig[i] {
+					sig[i] = h
+				}
+			}
+		}
+	}
+	return sig
+}
+
+// estimateJaccard estimates the Jaccard similarity of two columns' value
+// sets from their MinHash signatures in O(K), replacing overlapScore's
+// O(n*m) Cartesian Levenshtein comparison.
+func estimateJaccard(left, right []uint64) float64 {
+	if len(left) == 0 || len(right) == 0 || len(left) != len(right) {
+		return 0.0
+	}
+
+	matches := 0
+	for i := range left {
+		if left[i] == right[i] {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(left))
+}
+
+// lshIndex buckets MinHash signatures by band (b bands of r rows each, so
+// two signatures that agree on every row of any one band land in the same
+// bucket) to find candidate pairs in near-linear time instead of comparing
+// every left column against every right column.
+type lshIndex struct {
+	buckets []map[uint64][]int // one bucket map per band
+}
+
+func newLSHIndex() *lshIndex {
+	buckets := make([]map[uint64][]int, lshBands)
+	for i := range buckets {
+		buckets[i] = make(map[uint64][]int)
+	}
+	return &lshIndex{buckets: buckets}
+}
+
+func (idx *lshIndex) add(i int, sig []uint64) {
+	for b := 0; b < lshBands; b++ {
+		start, end := b*lshRows, b*lshRows+lshRows
+		if end > len(sig) {
+			break
+		}
+		h := bandHash(sig[start:end])
+		idx.buckets[b][h] = append(idx.buckets[b][h], i)
+	}
+}
+
+// candidates returns the indices that share at least one band bucket with
+// sig, deduplicated.
+func (idx *lshIndex) candidates(sig []uint64) []int {
+	seen := make(map[int]struct{})
+	var out []int
+	for b := 0; b < lshBands; b++ {
+		start, end := b*lshRows, b*lshRows+lshRows
+		if end > len(sig) {
+			break
+		}
+		h := bandHash(sig[start:end])
+		for _, i := range idx.buckets[b][h] {
+			if _, ok := seen[i]; !ok {
+				seen[i] = struct{}{}
+				out = append(out, i)
+			}
+		}
+	}
+	return out
+}
+
+func bandHash(band []uint64) uint64 {
+	h := uint64(14695981039346656037)
+	for _, v := range band {
+		h ^= v
+		h *= 1099511628211
+	}
+	return h
+}
+
+// lshMinSampleSize and lshMinPairs gate when MinHash/LSH estimation
+// actually engages. Below either threshold a K=128, b=16 signature is
+// either too noisy (too few shingled values to fill the per-function
+// minimums meaningfully) or band-gated candidates aren't worth the setup
+// cost, so matching falls back to the exact Cartesian Levenshtein overlap
+// even without --exact-overlap. This preserves the historical match
+// recall of small/default-sized runs - SampleSize defaults to 5 - from
+// before MinHash/LSH estimation landed.
+const (
+	lshMinSampleSize = 20
+	lshMinPairs      = 64
+)
+
+// resolveExactOverlap decides whether a match run should use the exact
+// overlap path: always when exactOverlap is explicitly set, and otherwise
+// whenever the input is too small for a MinHash/LSH estimate to be
+// reliable or worthwhile.
+func resolveExactOverlap(exactOverlap bool, leftCps, rightCps []ColumnProfile) bool {
+	if exactOverlap {
+		return true
+	}
+	if len(leftCps)*len(rightCps) < lshMinPairs {
+		return true
+	}
+	for _, cps := range [][]ColumnProfile{leftCps, rightCps} {
+		for _, cp := range cps {
+			if len(cp.Samples) < lshMinSampleSize {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package main
+
+import "testing"
+
+func TestMinHashSignatureDeterministic(t *testing.T) {
+	values := []string{"Jon Smith", "Jane Doe", "Jon  Smith"}
+	sig1 := minHashSignature(values)
+	sig2 := minHashSignature(values)
+
+	if len(sig1) != minHashFunctions {
+		t.Fatalf("signature length = %d, want %d", len(sig1), minHashFunctions)
+	}
+	for i := range sig1 {
+		if sig1[i] != sig2[i] {
+			t.Fatalf("signature not deterministic at index %d: %d != %d", i, sig1[i], sig2[i])
+		}
+	}
+}
+
+func TestCanonicalizeCollapsesNearDuplicates(t *testing.T) {
+	a := canonicalize("Jon Smith")
+	b := canonicalize("  jon  smith ")
+
+	if len(a) != len(b) {
+		t.Fatalf("shingle counts differ: %d vs %d", len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("shingle %d differs: %q != %q", i, a[i], b[i])
+		}
+	}
+}
+
+func TestEstimateJaccardIdenticalColumns(t *testing.T) {
+	sig := minHashSignature([]string{"alpha", "bravo", "charlie", "delta"})
+
+	if got := estimateJaccard(sig, sig); got != 1.0 {
+		t.Fatalf("estimateJaccard(sig, sig) = %v, want 1.0", got)
+	}
+}
+
+func TestEstimateJaccardDisjointColumns(t *testing.T) {
+	left := minHashSignature([]string{"alpha", "bravo"})
+	right := minHashSignature([]string{"123456", "789012"})
+
+	if got := estimateJaccard(left, right); got > 0.1 {
+		t.Fatalf("estimateJaccard for disjoint columns = %v, want close to 0", got)
+	}
+}
+
+func TestLSHIndexFindsIdenticalSignature(t *testing.T) {
+	sig := minHashSignature([]string{"alpha", "bravo", "charlie"})
+
+	idx := newLSHIndex()
+	idx.add(0, sig)
+
+	candidates := idx.candidates(sig)
+	if len(candidates) != 1 || candidates[0] != 0 {
+		t.Fatalf("candidates = %v, want [0]", candidates)
+	}
+}
+
+// TestLSHIndexMissesUnrelatedSignature documents lshIndex's own contract in
+// isolation: two signatures sharing no band don't land in the same bucket.
+// It says nothing about whether such a pair gets scored by matchProfile -
+// it does, with overlap treated as 0; see matcher_test.go.
+func TestLSHIndexMissesUnrelatedSignature(t *testing.T) {
+	idx := newLSHIndex()
+	idx.add(0, minHashSignature([]string{"alpha", "bravo", "charlie"}))
+
+	unrelated := minHashSignature([]string{"123456", "789012", "345678"})
+	if candidates := idx.candidates(unrelated); len(candidates) != 0 {
+		t.Fatalf("candidates = %v, want none", candidates)
+	}
+}
+
+func TestResolveExactOverlapDefaultsSmallRunsToExact(t *testing.T) {
+	small := []ColumnProfile{{Name: "a", Samples: []string{"1", "2", "3"}}}
+
+	if !resolveExactOverlap(false, small, small) {
+		t.Fatal("resolveExactOverlap should default small runs to exact")
+	}
+}
+
+func TestResolveExactOverlapHonoursExplicitFlag(t *testing.T) {
+	if !resolveExactOverlap(true, nil, nil) {
+		t.Fatal("resolveExactOverlap(true, ...) should always be exact")
+	}
+}
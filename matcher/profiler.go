@@ -3,10 +3,7 @@ package main
 import (
 	"database/sql"
 	"fmt"
-	"path/filepath"
 	"strings"
-
-	_ "github.com/marcboeker/go-duckdb/v2"
 )
 
 type Dtype string
@@ -55,6 +52,7 @@ type ColumnProfile struct {
 	NullPct   float64  `json:"null_pct"`
 	UniquePct float64  `json:"unique_pct"`
 	Samples   []string `json:"sample_values"`
+	Signature []uint64 `json:"signature,omitempty"`
 	Stats     any      `json:"stats"`
 }
 
@@ -78,68 +76,35 @@ func (cp ColumnProfile) populateSamples(samples []any) ColumnProfile {
 	for i, s := range samples {
 		cp.Samples[i] = fmt.Sprintf("%v", s)
 	}
+	cp.Signature = minHashSignature(cp.Samples)
 	return cp
 }
 
 func runProfile(p ProfileCmd) ([]ColumnProfile, error) {
+	if p.Source != "" {
+		return profileURI(p.Source, p.SampleSize)
+	}
 	return profilePath(p.Path, p.SampleSize)
 }
 
+// profilePath profiles a local CSV/Parquet file through the DuckDB source.
 func profilePath(path string, sampleSize int) ([]ColumnProfile, error) {
-	filename, err := filename(path)
-	if err != nil {
-		return nil, err
-	}
-	db, err := prepareDB(filename)
+	src, err := newDuckDBSource(path)
 	if err != nil {
 		return nil, err
 	}
-	defer db.Close()
-
-	cps, err := profile(db, filename, sampleSize)
-	if err != nil {
-		return []ColumnProfile{}, err
-	}
+	defer src.Close()
 
-	return cps, nil
+	return profileSource(src, sampleSize)
 }
 
-func prepareDB(filename string) (*sql.DB, error) {
-	db, err := sql.Open("duckdb", "")
-	if err != nil {
-		return nil, err
-	}
-
-	tableName := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
-	query := fmt.Sprintf("CREATE TEMP TABLE \"%s\" AS SELECT * FROM read_csv(\"%s\", nullstr = ['null', \"''\"], null_padding = true)", tableName, filename)
-	_, err = db.Exec(query)
-	if err != nil {
-		return nil, err
+// profileSide profiles either a --source URI, if given, or a local path.
+// Used by MatchCmd, which profiles an independent left and right side.
+func profileSide(path, source string, sampleSize int) ([]ColumnProfile, error) {
+	if source != "" {
+		return profileURI(source, sampleSize)
 	}
-
-	return db, nil
-}
-
-// parallelize the queries
-func profile(db *sql.DB, filename string, sampleSize int) ([]ColumnProfile, error) {
-	tableName := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
-
-	cps, err := tableInfo(db, tableName)
-	if err != nil {
-		return []ColumnProfile{}, err
-	}
-
-	cps, err = pcts(db, tableName, cps)
-	if err != nil {
-		return []ColumnProfile{}, err
-	}
-
-	cps, err = samples(db, tableName, sampleSize, cps)
-	if err != nil {
-		return []ColumnProfile{}, err
-	}
-
-	return cps, nil
+	return profilePath(path, sampleSize)
 }
 
 func tableInfo(db *sql.DB, tableName string) ([]ColumnProfile, error) {
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"net/rpc"
+	"runtime"
+)
+
+type ServeCmd struct {
+	Addr    string `help:"Address for the HTTP REST API and pprof" default:":8080" name:"addr"`
+	RPCAddr string `help:"Address for the shard-mapper RPC protocol used by --peers" default:":8081" name:"rpc-addr"`
+}
+
+func (s *ServeCmd) Run() error {
+	if err := rpc.Register(MapperService{}); err != nil {
+		return err
+	}
+	rpcListener, err := net.Listen("tcp", s.RPCAddr)
+	if err != nil {
+		return err
+	}
+	go rpc.Accept(rpcListener)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/profile", handleProfile)
+	mux.HandleFunc("/match", handleMatch)
+	mountPprof(mux)
+
+	fmt.Printf("dataswipe serve listening on %s (rpc on %s)\n", s.Addr, s.RPCAddr)
+	return http.ListenAndServe(s.Addr, mux)
+}
+
+// MapperService exposes localMapper over RPC so that a peer dataswipe
+// serve node can run a shard of a --peers distributed match on our behalf.
+type MapperService struct{}
+
+// Shard is the exported wire form of shard: net/rpc only registers methods
+// whose argument type is exported. gob matches struct fields by name, so a
+// shard sent by remoteMapper decodes into a Shard here.
+type Shard shard
+
+func (MapperService) MapShard(s Shard, scores *[]ColumnProfilePairScores) error {
+	lm := &localMapper{workers: runtime.NumCPU()}
+	res, err := lm.mapShard(shard(s))
+	if err != nil {
+		return err
+	}
+	*scores = res
+	return nil
+}
+
+type profileRequest struct {
+	Path       string `json:"path"`
+	Source     string `json:"source"`
+	SampleSize int    `json:"sample_size"`
+}
+
+func handleProfile(w http.ResponseWriter, r *http.Request) {
+	var req profileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.SampleSize == 0 {
+		req.SampleSize = 5
+	}
+
+	cps, err := runProfile(ProfileCmd{Path: req.Path, Source: req.Source, SampleSize: req.SampleSize})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cps)
+}
+
+type matchRequest struct {
+	LeftPath    string `json:"left_path"`
+	LeftSource  string `json:"left_source"`
+	RightPath   string `json:"right_path"`
+	RightSource string `json:"right_source"`
+	SampleSize  int    `json:"sample_size"`
+}
+
+func handleMatch(w http.ResponseWriter, r *http.Request) {
+	var req matchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.SampleSize == 0 {
+		req.SampleSize = 5
+	}
+
+	scores, err := runMatch(MatchCmd{
+		LeftPath: req.LeftPath, LeftSource: req.LeftSource,
+		RightPath: req.RightPath, RightSource: req.RightSource,
+		SampleSize: req.SampleSize,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scores)
+}
+
+// mountPprof exposes net/http/pprof under /debug/pprof/, including block and
+// mutex contention profiles. Both already honour a ?seconds=N query
+// parameter as of Go 1.15, returning the delta between two samples taken N
+// seconds apart rather than a cumulative snapshot. Block/mutex sampling is
+// off by default, so it's enabled here to make those deltas meaningful -
+// useful for diagnosing contention in the --workers/--peers distributed
+// match path.
+func mountPprof(mux *http.ServeMux) {
+	runtime.SetBlockProfileRate(1)
+	runtime.SetMutexProfileFraction(1)
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/pprof/block", pprof.Handler("block"))
+	mux.Handle("/debug/pprof/mutex", pprof.Handler("mutex"))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Source abstracts a queryable table, whether it backs onto an in-memory
+// DuckDB instance loaded from a local CSV/Parquet file, or a live database
+// connection. profileSource drives any Source through the same
+// tableInfo -> pcts -> samples pipeline profilePath used to run directly
+// against DuckDB.
+type Source interface {
+	TableInfo() ([]ColumnProfile, error)
+	Percentiles(cps []ColumnProfile) ([]ColumnProfile, error)
+	Sample(sampleSize int, cps []ColumnProfile) ([]ColumnProfile, error)
+	Close() error
+}
+
+// openSource parses a --source URI and returns the backend it selects.
+// Supported schemes are duckdb:// (or a bare filesystem path) for local
+// CSV/Parquet files, pg:// for Postgres, and oracle:// for Oracle.
+func openSource(uri string) (Source, error) {
+	if !strings.Contains(uri, "://") {
+		// Bare path: profile a local CSV/Parquet file through DuckDB.
+		return newDuckDBSource(uri)
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source %q: %w", uri, err)
+	}
+
+	switch u.Scheme {
+	case "duckdb":
+		return newDuckDBSource(u.Host + u.Path)
+	case "pg", "postgres", "postgresql":
+		return newPostgresSource(u)
+	case "oracle":
+		return newOracleSource(u)
+	default:
+		return nil, fmt.Errorf("unsupported source scheme %q", u.Scheme)
+	}
+}
+
+// profileSource runs the profiling pipeline against any Source
+// implementation.
+func profileSource(src Source, sampleSize int) ([]ColumnProfile, error) {
+	cps, err := src.TableInfo()
+	if err != nil {
+		return []ColumnProfile{}, err
+	}
+
+	cps, err = src.Percentiles(cps)
+	if err != nil {
+		return []ColumnProfile{}, err
+	}
+
+	cps, err = src.Sample(sampleSize, cps)
+	if err != nil {
+		return []ColumnProfile{}, err
+	}
+
+	return cps, nil
+}
+
+// profileURI opens a --source URI and profiles it, closing the source
+// afterwards.
+func profileURI(uri string, sampleSize int) ([]ColumnProfile, error) {
+	src, err := openSource(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return profileSource(src, sampleSize)
+}
+
+// identifierRE allow-lists table/column names before they're interpolated
+// into a query: a leading letter or underscore followed by letters, digits,
+// or underscores. This rejects anything that isn't a plain identifier
+// rather than trying to escape arbitrary input.
+var identifierRE = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// quoteIdentifier validates name against identifierRE and double-quotes it,
+// doubling any embedded quote per the SQL identifier-quoting convention
+// both Postgres and Oracle follow. Used instead of fmt's %q (which applies
+// Go string escaping, not SQL identifier escaping) for every table/column
+// name interpolated into a live-source query.
+func quoteIdentifier(name string) (string, error) {
+	if !identifierRE.MatchString(name) {
+		return "", fmt.Errorf("invalid identifier %q", name)
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`, nil
+}
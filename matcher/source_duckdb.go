@@ -0,0 +1,60 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/marcboeker/go-duckdb/v2"
+)
+
+// duckDBSource profiles a local CSV or Parquet file by loading it into an
+// in-memory DuckDB table.
+type duckDBSource struct {
+	db        *sql.DB
+	tableName string
+}
+
+func newDuckDBSource(path string) (Source, error) {
+	filename, err := filename(path)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("duckdb", "")
+	if err != nil {
+		return nil, err
+	}
+
+	tableName := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+
+	var query string
+	if strings.EqualFold(filepath.Ext(filename), ".parquet") {
+		query = fmt.Sprintf("CREATE TEMP TABLE \"%s\" AS SELECT * FROM read_parquet(\"%s\")", tableName, filename)
+	} else {
+		query = fmt.Sprintf("CREATE TEMP TABLE \"%s\" AS SELECT * FROM read_csv(\"%s\", nullstr = ['null', \"''\"], null_padding = true)", tableName, filename)
+	}
+	if _, err := db.Exec(query); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &duckDBSource{db: db, tableName: tableName}, nil
+}
+
+func (s *duckDBSource) TableInfo() ([]ColumnProfile, error) {
+	return tableInfo(s.db, s.tableName)
+}
+
+func (s *duckDBSource) Percentiles(cps []ColumnProfile) ([]ColumnProfile, error) {
+	return pcts(s.db, s.tableName, cps)
+}
+
+func (s *duckDBSource) Sample(sampleSize int, cps []ColumnProfile) ([]ColumnProfile, error) {
+	return samples(s.db, s.tableName, sampleSize, cps)
+}
+
+func (s *duckDBSource) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postgresSource profiles a live Postgres table reached via pgx, selected
+// with a pg://user:pass@host:port/db?table=foo source URI.
+type postgresSource struct {
+	pool  *pgxpool.Pool
+	table string
+}
+
+func newPostgresSource(u *url.URL) (Source, error) {
+	table := u.Query().Get("table")
+	if table == "" {
+		return nil, fmt.Errorf("pg source requires a ?table= query parameter: %s", u.Redacted())
+	}
+	if _, err := quoteIdentifier(table); err != nil {
+		return nil, fmt.Errorf("pg source table: %w", err)
+	}
+
+	dsn := *u
+	dsn.Scheme = "postgres"
+	q := dsn.Query()
+	q.Del("table")
+	dsn.RawQuery = q.Encode()
+
+	pool, err := pgxpool.New(context.Background(), dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("connect to postgres: %w", err)
+	}
+
+	return &postgresSource{pool: pool, table: table}, nil
+}
+
+func (s *postgresSource) TableInfo() ([]ColumnProfile, error) {
+	rows, err := s.pool.Query(context.Background(),
+		`SELECT column_name, data_type FROM information_schema.columns WHERE table_name = $1 ORDER BY ordinal_position`,
+		s.table)
+	if err != nil {
+		return []ColumnProfile{}, err
+	}
+	defer rows.Close()
+
+	cps := []ColumnProfile{}
+	for rows.Next() {
+		var name, dtype string
+		if err := rows.Scan(&name, &dtype); err != nil {
+			return []ColumnProfile{}, err
+		}
+		cps = append(cps, ColumnProfile{Name: name, DType: postgresDtype(dtype)})
+	}
+	return cps, rows.Err()
+}
+
+func (s *postgresSource) Percentiles(cps []ColumnProfile) ([]ColumnProfile, error) {
+	ctx := context.Background()
+	table, err := quoteIdentifier(s.table)
+	if err != nil {
+		return []ColumnProfile{}, err
+	}
+
+	for i, cp := range cps {
+		col, err := quoteIdentifier(cp.Name)
+		if err != nil {
+			return []ColumnProfile{}, err
+		}
+
+		query := fmt.Sprintf(
+			`SELECT 100.0 * COUNT(DISTINCT %s) / NULLIF(COUNT(*), 0), 100.0 * COUNT(*) FILTER (WHERE %s IS NULL) / NULLIF(COUNT(*), 0) FROM %s`,
+			col, col, table)
+
+		var uniquePct, nullPct float64
+		if err := s.pool.QueryRow(ctx, query).Scan(&uniquePct, &nullPct); err != nil {
+			return []ColumnProfile{}, err
+		}
+		cps[i] = cp.populatePcts(nullPct, uniquePct)
+	}
+	return cps, nil
+}
+
+func (s *postgresSource) Sample(sampleSize int, cps []ColumnProfile) ([]ColumnProfile, error) {
+	ctx := context.Background()
+	table, err := quoteIdentifier(s.table)
+	if err != nil {
+		return []ColumnProfile{}, err
+	}
+
+	query := fmt.Sprintf(`SELECT * FROM %s ORDER BY random() LIMIT %d`, table, sampleSize)
+	rows, err := s.pool.Query(ctx, query)
+	if err != nil {
+		return []ColumnProfile{}, err
+	}
+	defer rows.Close()
+
+	cum := make([][]any, len(cps))
+	for rows.Next() {
+		vals, err := rows.Values()
+		if err != nil {
+			return []ColumnProfile{}, err
+		}
+		for i, v := range vals {
+			cum[i] = append(cum[i], v)
+		}
+	}
+
+	for i, samp := range cum {
+		cps[i] = cps[i].populateSamples(samp)
+	}
+	return cps, rows.Err()
+}
+
+func (s *postgresSource) Close() error {
+	s.pool.Close()
+	return nil
+}
+
+// postgresDtype maps a Postgres information_schema.columns.data_type value
+// onto the internal Dtype used by sameFamily/castableLossy.
+func postgresDtype(pgType string) Dtype {
+	switch pgType {
+	case "smallint":
+		return SmallInt
+	case "integer":
+		return Integer
+	case "bigint":
+		return BigInt
+	case "numeric", "decimal":
+		return Decimal
+	case "real":
+		return Float
+	case "double precision":
+		return Double
+	case "boolean":
+		return Boolean
+	case "date":
+		return Date
+	case "time without time zone", "time with time zone":
+		return Time
+	case "timestamp without time zone":
+		return Timestamp
+	case "timestamp with time zone":
+		return TimestampTZ
+	case "uuid":
+		return UUID
+	case "json", "jsonb":
+		return JSON
+	case "bit", "bit varying":
+		return Bit
+	default:
+		return VarChar
+	}
+}
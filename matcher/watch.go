@@ -0,0 +1,140 @@
+package main
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+type WatchCmd struct {
+	Dir        string        `arg:"" required:"" name:"dir" help:"Directory to watch for CSV files" type:"path"`
+	Interval   time.Duration `help:"How often to re-profile the directory" default:"5m"`
+	Duration   time.Duration `help:"Total duration to capture for" default:"24h"`
+	Out        string        `help:"Output tar.zst capture artifact" default:"capture.tar.zst" type:"path"`
+	SampleSize int           `help:"Rows to sample per column" default:"5"`
+}
+
+func (w *WatchCmd) Run() error {
+	return watch(*w)
+}
+
+// watch re-profiles every .csv file under w.Dir every w.Interval, appending
+// each snapshot plus a match matrix against the previous one into a single
+// w.Out tar.zst capture artifact, for w.Duration.
+func watch(w WatchCmd) error {
+	f, err := os.Create(w.Out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw, err := zstd.NewWriter(f)
+	if err != nil {
+		return err
+	}
+	defer zw.Close()
+
+	tw := tar.NewWriter(zw)
+	defer tw.Close()
+
+	// Snapshotting the capture ID once, rather than per-interval, keeps
+	// every sub-capture in this artifact attributable to the same run.
+	captureID := time.Now().UTC().Format("20060102T150405Z")
+	deadline := time.Now().Add(w.Duration)
+
+	var prev map[string][]ColumnProfile
+	for {
+		snapshot, err := snapshotDir(w.Dir, w.SampleSize)
+		if err != nil {
+			return err
+		}
+
+		ts := time.Now().UTC().Format("20060102T150405Z")
+		if err := writeSnapshot(tw, captureID, ts, snapshot, prev); err != nil {
+			return err
+		}
+		prev = snapshot
+
+		if !time.Now().Before(deadline) {
+			return nil
+		}
+		time.Sleep(w.Interval)
+	}
+}
+
+// snapshotDir profiles every .csv file found by walking dir, keyed by its
+// path relative to dir.
+func snapshotDir(dir string, sampleSize int) (map[string][]ColumnProfile, error) {
+	snapshot := make(map[string][]ColumnProfile)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".csv") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		cps, err := profilePath(path, sampleSize)
+		if err != nil {
+			return err
+		}
+		snapshot[rel] = cps
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// writeSnapshot appends one interval's profiles, plus a match matrix
+// against the previous interval's profile of the same file (schema/statistic
+// drift - null-pct spikes, unique-pct collapse, new columns - shows up as a
+// low-scoring or missing match), under captureID/ts/ in tw.
+func writeSnapshot(tw *tar.Writer, captureID, ts string, snapshot, prev map[string][]ColumnProfile) error {
+	for name, cps := range snapshot {
+		data, err := json.Marshal(cps)
+		if err != nil {
+			return err
+		}
+		if err := addTarEntry(tw, filepath.Join(captureID, ts, name+".json"), data); err != nil {
+			return err
+		}
+
+		prevCps, ok := prev[name]
+		if !ok {
+			continue
+		}
+		matchData, err := json.Marshal(matchProfile(prevCps, cps, resolveExactOverlap(false, prevCps, cps)))
+		if err != nil {
+			return err
+		}
+		if err := addTarEntry(tw, filepath.Join(captureID, ts, "match-"+name+".json"), matchData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addTarEntry(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(data)),
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}